@@ -0,0 +1,57 @@
+// Package export implements the `lbtas export` subcommand.
+//
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+package export
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/cmd/lbtas/internal/cliflags"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/pkg/lbtas"
+)
+
+// Command returns the `export` subcommand, which writes the full ratings
+// store to the <output> path argument in --format (json or csv; a --format
+// given before the subcommand name is honoured too).
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "export",
+		Usage:     "export all ratings to a file",
+		ArgsUsage: "<output>",
+		Flags:     []cli.Flag{cliflags.Storage(), cliflags.Categories(), cliflags.Format()},
+		Action:    runExport,
+	}
+}
+
+func runExport(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return cli.Exit("Error: output path required", 1)
+	}
+	if c.NArg() > 1 {
+		return cli.Exit(fmt.Sprintf("Error: unexpected extra arguments %v - flags must come before <output>", c.Args().Slice()[1:]), 1)
+	}
+	output := c.Args().First()
+	format := cliflags.String(c, "format")
+
+	system := lbtas.New(cliflags.String(c, "storage"), cliflags.StringSlice(c, "categories"))
+
+	var err error
+	switch format {
+	case "json":
+		err = system.ExportToJSON(output)
+	case "csv":
+		err = system.ExportToCSV(output)
+	default:
+		return cli.Exit("Error: format must be json or csv", 1)
+	}
+
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("Error: %v", err), 1)
+	}
+
+	fmt.Printf("Exported to %s\n", output)
+	return nil
+}
@@ -0,0 +1,80 @@
+// Package cliflags holds the --storage/--categories/--format/--aggregator
+// flags shared by most lbtas subcommands.
+//
+// urfave/cli only accepts a flag on the FlagSet of whichever command is
+// actually parsing the arguments it appears among, so a flag declared only
+// on the root App is rejected ("flag provided but not defined") if it's
+// typed after the subcommand name rather than before it. Every leaf command
+// that reads one of these flags must redeclare it via the constructors
+// here - but doing so introduces a second problem: once the same flag name
+// is registered on both the App and a Command, cli.Context's lookup always
+// resolves to the innermost (leaf) FlagSet, so a value set at the App level
+// (before the subcommand name) is silently shadowed by the leaf's default.
+// String and StringSlice work around that by checking the context lineage
+// themselves instead of trusting cli.Context's built-in lookup.
+//
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+package cliflags
+
+import "github.com/urfave/cli/v2"
+
+// Storage returns the --storage flag, pointing at the ratings storage file.
+func Storage() cli.Flag {
+	return &cli.StringFlag{
+		Name:    "storage",
+		Value:   "lbtas_ratings.json",
+		Usage:   "path to the ratings storage file",
+		EnvVars: []string{"LBTAS_STORAGE"},
+	}
+}
+
+// Categories returns the --categories flag, overriding the default rating
+// categories.
+func Categories() cli.Flag {
+	return &cli.StringSliceFlag{
+		Name:  "categories",
+		Usage: "override the default rating categories",
+	}
+}
+
+// Format returns the --format flag, selecting an output format.
+func Format() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "format",
+		Value: "json",
+		Usage: "output format for export and summarize (json or csv)",
+	}
+}
+
+// Aggregator returns the --aggregator flag, selecting a rating aggregation
+// strategy.
+func Aggregator() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "aggregator",
+		Value: "mean",
+		Usage: "rating aggregation strategy: mean, safety_weighted, trimmed_mean, bayesian, or recency_decay",
+	}
+}
+
+// String reads a string flag shared between the root App and a leaf
+// Command, preferring whichever context in c's lineage actually had it set.
+// Use this instead of c.String for any flag declared via this package.
+func String(c *cli.Context, name string) string {
+	for _, ctx := range c.Lineage() {
+		if ctx.IsSet(name) {
+			return ctx.String(name)
+		}
+	}
+	return c.String(name)
+}
+
+// StringSlice is String for slice-valued flags like --categories.
+func StringSlice(c *cli.Context, name string) []string {
+	for _, ctx := range c.Lineage() {
+		if ctx.IsSet(name) {
+			return ctx.StringSlice(name)
+		}
+	}
+	return c.StringSlice(name)
+}
@@ -0,0 +1,66 @@
+// Command lbtas is the Leveson-Based Trade Assessment Scale CLI.
+//
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/cmd/lbtas/export"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/cmd/lbtas/internal/cliflags"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/cmd/lbtas/rate"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/cmd/lbtas/record"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/cmd/lbtas/replay"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/cmd/lbtas/report"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/cmd/lbtas/serve"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/cmd/lbtas/summarize"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/pkg/lbtas"
+)
+
+// newApp builds the lbtas CLI application. Split out from main so tests can
+// exercise argument parsing without an os.Exit in the way.
+func newApp() *cli.App {
+	return &cli.App{
+		Name:    "lbtas",
+		Usage:   "Leveson-Based Trade Assessment Scale",
+		Version: lbtas.Version,
+		Flags: []cli.Flag{
+			cliflags.Storage(),
+			cliflags.Categories(),
+			cliflags.Format(),
+			cliflags.Aggregator(),
+		},
+		Commands: []*cli.Command{
+			rate.Command(),
+			rate.AddCommand(),
+			report.Command(),
+			report.ViewCommand(),
+			report.ListCommand(),
+			export.Command(),
+			serve.Command(),
+			record.Command(),
+			replay.Command(),
+			summarize.Command(),
+		},
+		// Return ExitCoder errors to the caller instead of letting the
+		// default handler os.Exit directly, so main (and tests) can inspect
+		// the error.
+		ExitErrHandler: func(*cli.Context, error) {},
+	}
+}
+
+func main() {
+	if err := newApp().Run(os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
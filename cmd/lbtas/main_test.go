@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFlagPlacement exercises the three places --storage can appear relative
+// to "add"'s positional arguments: before the subcommand name, after the
+// subcommand name but before the positionals, and after the positionals.
+// The first two must write to the given storage file; the third has no
+// well-defined target position left to parse a flag from and must fail
+// loudly rather than silently falling back to the default storage file.
+func TestFlagPlacement(t *testing.T) {
+	run := func(t *testing.T, args ...string) error {
+		t.Helper()
+		return newApp().Run(append([]string{"lbtas"}, args...))
+	}
+
+	t.Run("before subcommand", func(t *testing.T) {
+		storage := filepath.Join(t.TempDir(), "ratings.json")
+		if err := run(t, "--storage", storage, "add", "nile", "reliability", "3"); err != nil {
+			t.Fatalf("run: %v", err)
+		}
+		if _, err := os.Stat(storage); err != nil {
+			t.Fatalf("expected %s to be written: %v", storage, err)
+		}
+	})
+
+	t.Run("after subcommand before positionals", func(t *testing.T) {
+		storage := filepath.Join(t.TempDir(), "ratings.json")
+		if err := run(t, "add", "--storage", storage, "nile", "reliability", "3"); err != nil {
+			t.Fatalf("run: %v", err)
+		}
+		if _, err := os.Stat(storage); err != nil {
+			t.Fatalf("expected %s to be written: %v", storage, err)
+		}
+	})
+
+	t.Run("after positionals is rejected, not silently dropped", func(t *testing.T) {
+		dir := t.TempDir()
+		wd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chdir(dir); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(wd)
+
+		storage := filepath.Join(dir, "ratings.json")
+		err = run(t, "add", "nile", "reliability", "3", "--storage", storage)
+		if err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+		if _, statErr := os.Stat(storage); statErr == nil {
+			t.Fatalf("--storage after positionals must not be silently honored: %s was written", storage)
+		}
+		if _, statErr := os.Stat(filepath.Join(dir, "lbtas_ratings.json")); statErr == nil {
+			t.Fatal("--storage after positionals must not silently fall back to the default storage file either")
+		}
+	})
+}
@@ -0,0 +1,139 @@
+// Package rate implements the `lbtas rate` and `lbtas add` subcommands,
+// which record ratings for an exchange against the LBTAS categories.
+//
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+package rate
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/cmd/lbtas/internal/cliflags"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/pkg/lbtas"
+)
+
+// Command returns the `rate` subcommand, which walks the user through an
+// interactive prompt for every category.
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "rate",
+		Usage:     "interactively rate an exchange across all categories",
+		ArgsUsage: "<exchange>",
+		Flags:     []cli.Flag{cliflags.Storage(), cliflags.Categories()},
+		Action:    runRate,
+	}
+}
+
+// AddCommand returns the `add` subcommand, which records a single rating
+// non-interactively.
+func AddCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "add",
+		Usage:     "add a single rating for an exchange and criterion",
+		ArgsUsage: "<exchange> <criterion> <rating>",
+		Flags:     []cli.Flag{cliflags.Storage(), cliflags.Categories()},
+		Action:    runAdd,
+	}
+}
+
+func newSystem(c *cli.Context) *lbtas.LevesonRatingSystem {
+	return lbtas.New(cliflags.String(c, "storage"), cliflags.StringSlice(c, "categories"))
+}
+
+func runRate(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return cli.Exit("Error: exchange name required", 1)
+	}
+	if c.NArg() > 1 {
+		return cli.Exit(fmt.Sprintf("Error: unexpected extra arguments %v - flags must come before <exchange>", c.Args().Slice()[1:]), 1)
+	}
+	exchange := c.Args().First()
+
+	system := newSystem(c)
+	if !lbtas.Contains(system.GetAllExchanges(), exchange) {
+		if err := system.AddExchange(exchange); err != nil {
+			return cli.Exit(fmt.Sprintf("Error: %v", err), 1)
+		}
+	}
+
+	fmt.Printf("\nRating '%s' using Leveson-Based Trade Assessment Scale\n", exchange)
+	fmt.Println(strings.Repeat("=", 60))
+
+	for _, criterion := range system.Categories() {
+		rating, err := promptRating(criterion)
+		if err != nil {
+			return cli.Exit(fmt.Sprintf("Error: %v", err), 1)
+		}
+
+		if err := system.AddRating(exchange, criterion, rating); err != nil {
+			return cli.Exit(fmt.Sprintf("Error: %v", err), 1)
+		}
+	}
+
+	fmt.Printf("\nRating completed for '%s'!\n", exchange)
+	return nil
+}
+
+func runAdd(c *cli.Context) error {
+	if c.NArg() < 3 {
+		return cli.Exit("Error: exchange, criterion, and rating required", 1)
+	}
+	if c.NArg() > 3 {
+		return cli.Exit(fmt.Sprintf("Error: unexpected extra arguments %v - flags must come before <exchange> <criterion> <rating>", c.Args().Slice()[3:]), 1)
+	}
+
+	exchange := c.Args().Get(0)
+	criterion := c.Args().Get(1)
+	rating, err := strconv.Atoi(c.Args().Get(2))
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("Error: invalid rating: %v", err), 1)
+	}
+
+	system := newSystem(c)
+	if !lbtas.Contains(system.GetAllExchanges(), exchange) {
+		if err := system.AddExchange(exchange); err != nil {
+			return cli.Exit(fmt.Sprintf("Error: %v", err), 1)
+		}
+	}
+
+	if err := system.AddRating(exchange, criterion, rating); err != nil {
+		return cli.Exit(fmt.Sprintf("Error: %v", err), 1)
+	}
+
+	fmt.Printf("Added rating %d for %s to %s\n", rating, criterion, exchange)
+	return nil
+}
+
+// promptRating walks the user through the rating scale for criterion and
+// reads their choice from stdin.
+func promptRating(criterion string) (int, error) {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Printf("\nRate %s:\n", strings.Title(criterion))
+	fmt.Println(strings.Repeat("=", 50))
+
+	for rating := 4; rating >= -1; rating-- {
+		fmt.Printf(" %2d: %s\n", rating, lbtas.RatingDescriptions[rating])
+	}
+
+	fmt.Println(strings.Repeat("=", 50))
+
+	for {
+		fmt.Printf("Enter your rating for %s (-1 to 4): ", strings.Title(criterion))
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+
+		rating, err := strconv.Atoi(input)
+		if err == nil && rating >= -1 && rating <= 4 {
+			return rating, nil
+		}
+
+		fmt.Println("Please enter a rating between -1 and 4.")
+	}
+}
@@ -0,0 +1,82 @@
+// Package record implements the `lbtas record` subcommand, which adds a
+// rating the same way `lbtas add` does but also appends it to an
+// append-only JSONL event log for later `replay`/`summarize`.
+//
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+package record
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/cmd/lbtas/internal/cliflags"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/pkg/lbtas"
+)
+
+// Command returns the `record` subcommand.
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:      "record",
+		Usage:     "add a rating and append it to the event log",
+		ArgsUsage: "<exchange> <criterion> <rating> [rater] [note]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "log",
+				Value: "lbtas_events.jsonl",
+				Usage: "path to the append-only event log",
+			},
+			cliflags.Storage(),
+			cliflags.Categories(),
+		},
+		Action: runRecord,
+	}
+}
+
+func runRecord(c *cli.Context) error {
+	if c.NArg() < 3 {
+		return cli.Exit("Error: exchange, criterion, and rating required", 1)
+	}
+	if c.NArg() > 5 {
+		return cli.Exit(fmt.Sprintf("Error: unexpected extra arguments %v - flags must come before <exchange> <criterion> <rating> [rater] [note]", c.Args().Slice()[5:]), 1)
+	}
+
+	exchange := c.Args().Get(0)
+	criterion := c.Args().Get(1)
+	rating, err := strconv.Atoi(c.Args().Get(2))
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("Error: invalid rating: %v", err), 1)
+	}
+	rater := c.Args().Get(3)
+	note := c.Args().Get(4)
+
+	system := lbtas.New(cliflags.String(c, "storage"), cliflags.StringSlice(c, "categories"))
+	if !lbtas.Contains(system.GetAllExchanges(), exchange) {
+		if err := system.AddExchange(exchange); err != nil {
+			return cli.Exit(fmt.Sprintf("Error: %v", err), 1)
+		}
+	}
+
+	if err := system.AddRatingWithDetails(exchange, criterion, rating, rater, note); err != nil {
+		return cli.Exit(fmt.Sprintf("Error: %v", err), 1)
+	}
+
+	recorder := lbtas.NewRecorder(c.String("log"))
+	event := lbtas.Event{
+		Exchange:  exchange,
+		Criterion: criterion,
+		Rating:    rating,
+		Rater:     rater,
+		Note:      note,
+		At:        time.Now(),
+	}
+	if err := recorder.Append(event); err != nil {
+		return cli.Exit(fmt.Sprintf("Error: appending to event log: %v", err), 1)
+	}
+
+	fmt.Printf("Recorded rating %d for %s to %s\n", rating, criterion, exchange)
+	return nil
+}
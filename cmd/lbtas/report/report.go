@@ -0,0 +1,185 @@
+// Package report implements the read-only `lbtas report`, `lbtas view`, and
+// `lbtas list` subcommands.
+//
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/cmd/lbtas/internal/cliflags"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/pkg/lbtas"
+)
+
+// Command returns the `report` subcommand, which prints the system-wide
+// summary produced by GenerateReport.
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "report",
+		Usage: "print a system-wide ratings report",
+		Flags: append([]cli.Flag{
+			cliflags.Storage(),
+			cliflags.Categories(),
+			cliflags.Aggregator(),
+		}, &cli.StringFlag{
+			Name:  "since",
+			Usage: "only consider ratings from this far back, e.g. 7d, 24h (default: lifetime)",
+		}),
+		Action: runReport,
+	}
+}
+
+// ViewCommand returns the `view` subcommand, which prints per-category
+// averages for a single exchange.
+func ViewCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "view",
+		Usage:     "view the per-category averages for an exchange",
+		ArgsUsage: "<exchange>",
+		Flags:     []cli.Flag{cliflags.Storage(), cliflags.Categories(), cliflags.Aggregator()},
+		Action:    runView,
+	}
+}
+
+// ListCommand returns the `list` subcommand, which prints every registered
+// exchange with its overall average.
+func ListCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "list",
+		Usage:  "list all registered exchanges",
+		Flags:  []cli.Flag{cliflags.Storage(), cliflags.Categories(), cliflags.Aggregator()},
+		Action: runList,
+	}
+}
+
+func newSystem(c *cli.Context) (*lbtas.LevesonRatingSystem, error) {
+	agg, err := lbtas.NewAggregator(cliflags.String(c, "aggregator"))
+	if err != nil {
+		return nil, err
+	}
+	return lbtas.New(cliflags.String(c, "storage"), cliflags.StringSlice(c, "categories"), lbtas.WithAggregator(agg)), nil
+}
+
+func runReport(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cli.Exit(fmt.Sprintf("Error: unexpected extra arguments %v - flags must come before the command", c.Args().Slice()), 1)
+	}
+
+	system, err := newSystem(c)
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("Error: %v", err), 1)
+	}
+
+	var window lbtas.TimeWindow
+	if since := c.String("since"); since != "" {
+		duration, err := lbtas.ParseDuration(since)
+		if err != nil {
+			return cli.Exit(fmt.Sprintf("Error: invalid --since: %v", err), 1)
+		}
+		window.From = time.Now().Add(-duration)
+	}
+
+	report := system.GenerateReport(window)
+
+	fmt.Println("\nLBTAS System Report")
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Total exchanges: %d\n", report.TotalExchanges)
+	fmt.Printf("Total ratings: %d\n", report.TotalRatings)
+	if report.SystemAverage != nil {
+		fmt.Printf("System average: %.2f\n", *report.SystemAverage)
+	}
+
+	if len(report.CategoryAverages) > 0 {
+		fmt.Println("\nCategory Averages:")
+		for _, category := range system.Categories() {
+			if avg := report.CategoryAverages[category]; avg != nil {
+				fmt.Printf("  %-12s: %.2f\n", strings.Title(category), *avg)
+			}
+		}
+	}
+
+	if len(report.TopPerformers) > 0 {
+		fmt.Println("\nTop Performers:")
+		for _, perf := range report.TopPerformers {
+			fmt.Printf("  %s: %.2f\n", perf.Name, perf.Average)
+		}
+	}
+
+	return nil
+}
+
+func runView(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return cli.Exit("Error: exchange name required", 1)
+	}
+	if c.NArg() > 1 {
+		return cli.Exit(fmt.Sprintf("Error: unexpected extra arguments %v - flags must come before <exchange>", c.Args().Slice()[1:]), 1)
+	}
+	exchange := c.Args().First()
+
+	system, err := newSystem(c)
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("Error: %v", err), 1)
+	}
+	ratings, err := system.ViewRatings(exchange)
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("Error: %v", err), 1)
+	}
+
+	fmt.Printf("\nRatings for '%s':\n", exchange)
+	fmt.Println(strings.Repeat("=", 40))
+	for _, criterion := range system.Categories() {
+		rating := ratings[criterion]
+		switch {
+		case rating == nil:
+			fmt.Printf("%-12s: No ratings\n", strings.Title(criterion))
+		case !rating.Sufficient:
+			fmt.Printf("%-12s: N/A - insufficient samples (n=%d)\n", strings.Title(criterion), rating.Count)
+		default:
+			fmt.Printf("%-12s: %4.2f  (n=%d, 95%% CI %.2f-%.2f)\n", strings.Title(criterion), rating.Average, rating.Count, rating.Low, rating.High)
+		}
+	}
+
+	return nil
+}
+
+func runList(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cli.Exit(fmt.Sprintf("Error: unexpected extra arguments %v - flags must come before the command", c.Args().Slice()), 1)
+	}
+
+	system, err := newSystem(c)
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("Error: %v", err), 1)
+	}
+	exchanges := system.GetAllExchanges()
+	if len(exchanges) == 0 {
+		fmt.Println("No exchanges registered.")
+		return nil
+	}
+
+	fmt.Println("Registered exchanges:")
+	for _, exchange := range exchanges {
+		ratings, _ := system.ViewRatings(exchange)
+		var sum float64
+		var count int
+		for _, rating := range ratings {
+			if rating != nil {
+				sum += rating.Average
+				count++
+			}
+		}
+		if count > 0 {
+			fmt.Printf("  %s (avg: %.2f)\n", exchange, sum/float64(count))
+		} else {
+			fmt.Printf("  %s (no ratings)\n", exchange)
+		}
+	}
+
+	return nil
+}
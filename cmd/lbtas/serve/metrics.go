@@ -0,0 +1,94 @@
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+package serve
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/pkg/lbtas"
+)
+
+var ratingsAddedTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "lbtas_ratings_added_total",
+		Help: "Number of ratings recorded, by criterion.",
+	},
+	[]string{"criterion"},
+)
+
+// reportCollector is a prometheus.Collector that reads gauge values out of
+// the rating system on every scrape, rather than pushing updates on every
+// write. This keeps AddRating free of Prometheus-specific bookkeeping beyond
+// the ratingsAddedTotal counter registered via OnRatingAdded.
+type reportCollector struct {
+	system *lbtas.LevesonRatingSystem
+
+	exchangeAverage *prometheus.Desc
+	systemAverage   *prometheus.Desc
+	totalRatings    *prometheus.Desc
+}
+
+func newReportCollector(system *lbtas.LevesonRatingSystem) *reportCollector {
+	return &reportCollector{
+		system: system,
+		exchangeAverage: prometheus.NewDesc(
+			"lbtas_exchange_average",
+			"Average rating for an exchange in a category.",
+			[]string{"exchange", "category"}, nil,
+		),
+		systemAverage: prometheus.NewDesc(
+			"lbtas_system_average",
+			"Average rating across all exchanges and categories.",
+			nil, nil,
+		),
+		totalRatings: prometheus.NewDesc(
+			"lbtas_total_ratings",
+			"Total number of ratings recorded.",
+			nil, nil,
+		),
+	}
+}
+
+func (c *reportCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.exchangeAverage
+	ch <- c.systemAverage
+	ch <- c.totalRatings
+}
+
+func (c *reportCollector) Collect(ch chan<- prometheus.Metric) {
+	report := c.system.GenerateReport(lbtas.TimeWindow{})
+
+	if report.SystemAverage != nil {
+		ch <- prometheus.MustNewConstMetric(c.systemAverage, prometheus.GaugeValue, *report.SystemAverage)
+	}
+	ch <- prometheus.MustNewConstMetric(c.totalRatings, prometheus.GaugeValue, float64(report.TotalRatings))
+
+	for _, name := range c.system.GetAllExchanges() {
+		averages, err := c.system.CategoryAverages(name)
+		if err != nil {
+			continue
+		}
+		for _, category := range c.system.Categories() {
+			avg, ok := averages[category]
+			if !ok {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(c.exchangeAverage, prometheus.GaugeValue, avg, name, category)
+		}
+	}
+}
+
+// registerMetrics wires system into a dedicated prometheus.Registry: the
+// report-derived gauges via reportCollector, and the ratings-added counter
+// via an AddRating hook.
+func registerMetrics(system *lbtas.LevesonRatingSystem) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(newReportCollector(system))
+	registry.MustRegister(ratingsAddedTotal)
+
+	system.OnRatingAdded(func(_, criterion string, _ int) {
+		ratingsAddedTotal.WithLabelValues(criterion).Inc()
+	})
+
+	return registry
+}
@@ -0,0 +1,217 @@
+// Package serve implements the `lbtas serve` subcommand, which exposes the
+// rating system over HTTP: a small REST/JSON API plus a Prometheus
+// `/metrics` endpoint for dashboards and alerting.
+//
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/urfave/cli/v2"
+
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/cmd/lbtas/internal/cliflags"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/pkg/alert"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/pkg/lbtas"
+)
+
+// Command returns the `serve` subcommand.
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "serve the rating system over HTTP",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "listen",
+				Value: ":8080",
+				Usage: "address to listen on",
+			},
+			&cli.StringFlag{
+				Name:  "alert-rules",
+				Usage: "path to a YAML or JSON alert rules file",
+			},
+			&cli.StringFlag{
+				Name:  "alert-webhook",
+				Usage: "AlertManager v2 webhook URL to post firing/resolved alerts to",
+			},
+			&cli.DurationFlag{
+				Name:  "alert-interval",
+				Value: time.Minute,
+				Usage: "how often to re-evaluate alert rules in the background",
+			},
+			cliflags.Storage(),
+			cliflags.Categories(),
+			cliflags.Aggregator(),
+		},
+		Action: runServe,
+	}
+}
+
+func runServe(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cli.Exit(fmt.Sprintf("Error: unexpected extra arguments %v - flags must come before the command", c.Args().Slice()), 1)
+	}
+
+	agg, err := lbtas.NewAggregator(cliflags.String(c, "aggregator"))
+	if err != nil {
+		return err
+	}
+	system := lbtas.New(cliflags.String(c, "storage"), cliflags.StringSlice(c, "categories"), lbtas.WithAggregator(agg))
+	registry := registerMetrics(system)
+
+	if err := setupAlerting(c, system); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/exchanges/", newExchangeHandler(system))
+	mux.HandleFunc("/report", newReportHandler(system, agg))
+	mux.HandleFunc("/export.csv", newExportHandler(system))
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	return http.ListenAndServe(c.String("listen"), mux)
+}
+
+// setupAlerting wires the alert.Manager into system, if --alert-rules was
+// given: rule evaluation runs on every AddRating and on a background ticker
+// so exchanges keep being checked even without new ratings.
+func setupAlerting(c *cli.Context, system *lbtas.LevesonRatingSystem) error {
+	rulesPath := c.String("alert-rules")
+	if rulesPath == "" {
+		return nil
+	}
+
+	rules, err := alert.LoadRules(rulesPath)
+	if err != nil {
+		return err
+	}
+
+	manager := alert.NewManager(rules, system, c.String("alert-webhook"))
+	system.OnRatingAdded(manager.Evaluate)
+	go manager.Run(context.Background(), c.Duration("alert-interval"))
+
+	return nil
+}
+
+// ratingRequest is the JSON body expected by POST /exchanges/{name}/ratings.
+type ratingRequest struct {
+	Criterion string `json:"criterion"`
+	Rating    int    `json:"rating"`
+}
+
+// newExchangeHandler serves GET /exchanges/{name} and
+// POST /exchanges/{name}/ratings.
+func newExchangeHandler(system *lbtas.LevesonRatingSystem) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/exchanges/")
+		parts := strings.Split(strings.Trim(path, "/"), "/")
+		if len(parts) == 0 || parts[0] == "" {
+			http.NotFound(w, r)
+			return
+		}
+		name := parts[0]
+
+		switch {
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			getExchange(w, system, name)
+		case len(parts) == 2 && parts[1] == "ratings" && r.Method == http.MethodPost:
+			postRating(w, r, system, name)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func getExchange(w http.ResponseWriter, system *lbtas.LevesonRatingSystem, name string) {
+	summary, err := system.ViewRatings(name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, summary)
+}
+
+func postRating(w http.ResponseWriter, r *http.Request, system *lbtas.LevesonRatingSystem, name string) {
+	var req ratingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !lbtas.Contains(system.GetAllExchanges(), name) {
+		if err := system.AddExchange(name); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := system.AddRating(name, req.Criterion, req.Rating); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// newReportHandler serves GET /report, optionally restricted to a window
+// via ?since=7d (or any lbtas.ParseDuration syntax) and/or computed with a
+// different aggregation strategy via ?aggregator=bayesian (see
+// lbtas.NewAggregator for valid names; default is the one the server was
+// started with).
+func newReportHandler(system *lbtas.LevesonRatingSystem, defaultAgg lbtas.Aggregator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var window lbtas.TimeWindow
+		if since := r.URL.Query().Get("since"); since != "" {
+			duration, err := lbtas.ParseDuration(since)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			window.From = time.Now().Add(-duration)
+		}
+
+		agg := defaultAgg
+		if name := r.URL.Query().Get("aggregator"); name != "" {
+			var err error
+			agg, err = lbtas.NewAggregator(name)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		writeJSON(w, system.GenerateReportWith(window, agg))
+	}
+}
+
+func newExportHandler(system *lbtas.LevesonRatingSystem) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		if err := system.WriteCSV(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
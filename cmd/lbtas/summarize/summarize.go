@@ -0,0 +1,111 @@
+// Package summarize implements the `lbtas summarize` subcommand, which
+// emits rolling per-bucket category averages, standard deviations, and
+// trend deltas from a `lbtas record` event log.
+//
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+package summarize
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/cmd/lbtas/internal/cliflags"
+	"github.com/NTARI-OpenCoreLab/Leveson-Based-Trade-Assessment-Scale/pkg/lbtas"
+)
+
+// Command returns the `summarize` subcommand.
+func Command() *cli.Command {
+	return &cli.Command{
+		Name:  "summarize",
+		Usage: "emit rolling category averages over a time window",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "log",
+				Value: "lbtas_events.jsonl",
+				Usage: "path to the append-only event log",
+			},
+			&cli.StringFlag{
+				Name:  "window",
+				Value: "30d",
+				Usage: "how far back to summarize, e.g. 30d, 72h",
+			},
+			&cli.StringFlag{
+				Name:  "bucket",
+				Value: "1d",
+				Usage: "bucket width, e.g. 1d, 6h",
+			},
+			cliflags.Categories(),
+			cliflags.Format(),
+		},
+		Action: runSummarize,
+	}
+}
+
+func runSummarize(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return cli.Exit(fmt.Sprintf("Error: unexpected extra arguments %v - flags must come before the command", c.Args().Slice()), 1)
+	}
+
+	window, err := lbtas.ParseDuration(c.String("window"))
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("Error: invalid --window: %v", err), 1)
+	}
+	bucket, err := lbtas.ParseDuration(c.String("bucket"))
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("Error: invalid --bucket: %v", err), 1)
+	}
+
+	events, err := lbtas.ReadEvents(c.String("log"))
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("Error: reading event log: %v", err), 1)
+	}
+
+	categories := cliflags.StringSlice(c, "categories")
+	if len(categories) == 0 {
+		categories = lbtas.DefaultCategories
+	}
+
+	timeWindow := lbtas.TimeWindow{From: time.Now().Add(-window)}
+	summaries := lbtas.Summarize(events, categories, timeWindow, bucket)
+
+	if cliflags.String(c, "format") == "csv" {
+		return writeCSV(summaries)
+	}
+	return writeJSON(summaries)
+}
+
+func writeJSON(summaries []lbtas.SeriesSummary) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(summaries)
+}
+
+func writeCSV(summaries []lbtas.SeriesSummary) error {
+	writer := csv.NewWriter(os.Stdout)
+	defer writer.Flush()
+
+	writer.Write([]string{"exchange", "category", "bucket_start", "average", "std_dev", "count", "trend_delta"})
+
+	for _, summary := range summaries {
+		for _, bucket := range summary.Buckets {
+			writer.Write([]string{
+				summary.Exchange,
+				summary.Category,
+				bucket.BucketStart.Format(time.RFC3339),
+				strconv.FormatFloat(bucket.Average, 'f', 4, 64),
+				strconv.FormatFloat(bucket.StdDev, 'f', 4, 64),
+				strconv.Itoa(bucket.Count),
+				strconv.FormatFloat(summary.TrendDelta, 'f', 4, 64),
+			})
+		}
+	}
+
+	return nil
+}
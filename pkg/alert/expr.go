@@ -0,0 +1,221 @@
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+package alert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Evaluate runs a condition expression against a series of ratings and
+// reports whether it holds.
+//
+// Expressions are a small subset of boolean algebra over aggregate
+// functions, e.g.:
+//
+//	avg_last_n(10) < 1
+//	min() < -1 || count_below(0) > 3
+//
+// Supported functions are avg(), avg_last_n(n), min(), and
+// count_below(threshold); terms may be combined with && and ||, with &&
+// binding tighter than ||.
+func Evaluate(expression string, ratings []int) (bool, error) {
+	p := &parser{tokens: tokenize(expression), ratings: ratings}
+	result, err := p.parseOr()
+	if err != nil {
+		return false, err
+	}
+	if p.pos != len(p.tokens) {
+		return false, fmt.Errorf("alert: unexpected token %q in expression %q", p.tokens[p.pos], expression)
+	}
+	return result, nil
+}
+
+func tokenize(expression string) []string {
+	expression = strings.NewReplacer(
+		"(", " ( ",
+		")", " ) ",
+		"&&", " && ",
+		"||", " || ",
+		"<=", " <= ",
+		">=", " >= ",
+		"==", " == ",
+		"<", " < ",
+		">", " > ",
+	).Replace(expression)
+	return strings.Fields(expression)
+}
+
+type parser struct {
+	tokens  []string
+	pos     int
+	ratings []int
+}
+
+func (p *parser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+// parseOr := parseAnd (("||") parseAnd)*
+func (p *parser) parseOr() (bool, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return false, err
+	}
+	for p.peek() == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return false, err
+		}
+		left = left || right
+	}
+	return left, nil
+}
+
+// parseAnd := term (("&&") term)*
+func (p *parser) parseAnd() (bool, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return false, err
+	}
+	for p.peek() == "&&" {
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return false, err
+		}
+		left = left && right
+	}
+	return left, nil
+}
+
+// parseTerm := funcCall comparator number
+func (p *parser) parseTerm() (bool, error) {
+	value, err := p.parseFuncCall()
+	if err != nil {
+		return false, err
+	}
+
+	comparator := p.next()
+	operand, err := p.parseNumber()
+	if err != nil {
+		return false, err
+	}
+
+	switch comparator {
+	case "<":
+		return value < operand, nil
+	case "<=":
+		return value <= operand, nil
+	case ">":
+		return value > operand, nil
+	case ">=":
+		return value >= operand, nil
+	case "==":
+		return value == operand, nil
+	default:
+		return false, fmt.Errorf("alert: expected comparator, got %q", comparator)
+	}
+}
+
+func (p *parser) parseFuncCall() (float64, error) {
+	name := p.next()
+
+	if p.next() != "(" {
+		return 0, fmt.Errorf("alert: expected '(' after %q", name)
+	}
+
+	var arg string
+	if p.peek() != ")" {
+		arg = p.next()
+	}
+
+	if p.next() != ")" {
+		return 0, fmt.Errorf("alert: expected ')' after %q(...)", name)
+	}
+
+	switch name {
+	case "avg":
+		return avg(p.ratings), nil
+	case "avg_last_n":
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return 0, fmt.Errorf("alert: avg_last_n expects an integer argument: %w", err)
+		}
+		return avgLastN(p.ratings, n), nil
+	case "min":
+		return min(p.ratings), nil
+	case "count_below":
+		threshold, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return 0, fmt.Errorf("alert: count_below expects a numeric argument: %w", err)
+		}
+		return countBelow(p.ratings, threshold), nil
+	default:
+		return 0, fmt.Errorf("alert: unknown function %q", name)
+	}
+}
+
+func (p *parser) parseNumber() (float64, error) {
+	tok := p.next()
+	value, err := strconv.ParseFloat(tok, 64)
+	if err != nil {
+		return 0, fmt.Errorf("alert: expected number, got %q", tok)
+	}
+	return value, nil
+}
+
+func avg(ratings []int) float64 {
+	if len(ratings) == 0 {
+		return 0
+	}
+	sum := 0
+	for _, r := range ratings {
+		sum += r
+	}
+	return float64(sum) / float64(len(ratings))
+}
+
+func avgLastN(ratings []int, n int) float64 {
+	if n <= 0 || len(ratings) == 0 {
+		return 0
+	}
+	if n > len(ratings) {
+		n = len(ratings)
+	}
+	return avg(ratings[len(ratings)-n:])
+}
+
+func min(ratings []int) float64 {
+	if len(ratings) == 0 {
+		return 0
+	}
+	m := ratings[0]
+	for _, r := range ratings[1:] {
+		if r < m {
+			m = r
+		}
+	}
+	return float64(m)
+}
+
+func countBelow(ratings []int, threshold float64) float64 {
+	count := 0
+	for _, r := range ratings {
+		if float64(r) < threshold {
+			count++
+		}
+	}
+	return float64(count)
+}
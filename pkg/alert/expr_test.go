@@ -0,0 +1,73 @@
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+package alert
+
+import "testing"
+
+func TestEvaluate(t *testing.T) {
+	ratings := []int{5, 4, 3, 2, 1}
+
+	cases := []struct {
+		name       string
+		expression string
+		want       bool
+	}{
+		{"avg below threshold", "avg() < 4", true},
+		{"avg above threshold", "avg() > 4", false},
+		{"avg_last_n narrows window", "avg_last_n(2) < 2", true},
+		{"avg_last_n clamps to length", "avg_last_n(100) == 3", true},
+		{"min", "min() <= 1", true},
+		{"count_below", "count_below(3) >= 2", true},
+		{"and both true", "avg() < 4 && min() < 2", true},
+		{"and one false", "avg() < 4 && min() > 2", false},
+		{"or one true", "avg() > 4 || min() < 2", true},
+		{"or both false", "avg() > 4 || min() > 2", false},
+		{"and binds tighter than or", "min() < 2 || avg() > 4 && count_below(3) > 2", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Evaluate(tc.expression, ratings)
+			if err != nil {
+				t.Fatalf("Evaluate(%q) returned error: %v", tc.expression, err)
+			}
+			if got != tc.want {
+				t.Errorf("Evaluate(%q) = %v, want %v", tc.expression, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateEmptyRatings(t *testing.T) {
+	got, err := Evaluate("avg() == 0 && min() == 0 && count_below(1) == 0", nil)
+	if err != nil {
+		t.Fatalf("Evaluate returned error: %v", err)
+	}
+	if !got {
+		t.Errorf("Evaluate with empty ratings = %v, want true", got)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	cases := []struct {
+		name       string
+		expression string
+	}{
+		{"unknown function", "bogus() < 1"},
+		{"missing comparator", "avg()"},
+		{"bad avg_last_n argument", "avg_last_n(x) < 1"},
+		{"bad count_below argument", "count_below(x) < 1"},
+		{"non numeric operand", "avg() < x"},
+		{"trailing tokens", "avg() < 1 garbage"},
+		{"missing open paren", "avg < 1"},
+		{"missing close paren", "avg( < 1"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Evaluate(tc.expression, []int{1}); err == nil {
+				t.Errorf("Evaluate(%q) = nil error, want error", tc.expression)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RatingSource is the slice of LevesonRatingSystem the alerting subsystem
+// depends on, kept narrow so it can be faked in tests.
+type RatingSource interface {
+	GetAllExchanges() []string
+	CategoryRatings(exchangeName, category string) ([]int, error)
+}
+
+// amAlert is a single alert in the Prometheus AlertManager v2 webhook
+// format.
+type amAlert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    string            `json:"startsAt,omitempty"`
+	EndsAt      string            `json:"endsAt,omitempty"`
+}
+
+type ruleState struct {
+	satisfiedSince time.Time
+	firing         bool
+}
+
+// Manager evaluates a fixed set of Rules against a RatingSource and posts
+// firing/resolved alerts to an AlertManager-compatible webhook, deduped and
+// resent per rule+exchange.
+type Manager struct {
+	rules      []Rule
+	source     RatingSource
+	webhookURL string
+	httpClient *http.Client
+
+	mu     sync.Mutex
+	states map[string]*ruleState
+}
+
+// NewManager constructs a Manager that evaluates rules against source and
+// posts to webhookURL.
+func NewManager(rules []Rule, source RatingSource, webhookURL string) *Manager {
+	return &Manager{
+		rules:      rules,
+		source:     source,
+		webhookURL: webhookURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		states:     make(map[string]*ruleState),
+	}
+}
+
+// Evaluate checks every rule scoped to exchangeName (or to all exchanges,
+// for rules with Exchange == "*"). It is meant to be wired into
+// LevesonRatingSystem.OnRatingAdded.
+func (m *Manager) Evaluate(exchangeName, _ string, _ int) {
+	for _, rule := range m.rules {
+		if rule.Exchange != "*" && rule.Exchange != exchangeName {
+			continue
+		}
+		m.evaluateRule(rule, exchangeName)
+	}
+}
+
+// EvaluateAll checks every rule against every exchange currently known to
+// the RatingSource. It is meant to be called on a background ticker so
+// alerts still fire even when an exchange receives no new ratings.
+func (m *Manager) EvaluateAll() {
+	exchanges := m.source.GetAllExchanges()
+	for _, rule := range m.rules {
+		if rule.Exchange == "*" {
+			for _, exchange := range exchanges {
+				m.evaluateRule(rule, exchange)
+			}
+			continue
+		}
+		m.evaluateRule(rule, rule.Exchange)
+	}
+}
+
+// Run evaluates all rules every interval until ctx is cancelled.
+func (m *Manager) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.EvaluateAll()
+		}
+	}
+}
+
+func (m *Manager) evaluateRule(rule Rule, exchangeName string) {
+	ratings, err := m.source.CategoryRatings(exchangeName, rule.Category)
+	if err != nil {
+		return
+	}
+
+	satisfied, err := Evaluate(rule.Condition, ratings)
+	if err != nil {
+		return
+	}
+
+	m.mu.Lock()
+	key := rule.key() + "/" + exchangeName
+	state, ok := m.states[key]
+	if !ok {
+		state = &ruleState{}
+		m.states[key] = state
+	}
+
+	now := timeNow()
+
+	if !satisfied {
+		wasFiring := state.firing
+		state.satisfiedSince = time.Time{}
+		state.firing = false
+		m.mu.Unlock()
+
+		if wasFiring {
+			m.post(rule, exchangeName, now, true)
+		}
+		return
+	}
+
+	if state.satisfiedSince.IsZero() {
+		state.satisfiedSince = now
+	}
+
+	shouldFire := state.firing || now.Sub(state.satisfiedSince) >= rule.For
+	state.firing = shouldFire
+	startedAt := state.satisfiedSince
+	m.mu.Unlock()
+
+	if shouldFire {
+		m.post(rule, exchangeName, startedAt, false)
+	}
+}
+
+func (m *Manager) post(rule Rule, exchangeName string, startsAt time.Time, resolved bool) {
+	if m.webhookURL == "" {
+		return
+	}
+
+	alert := amAlert{
+		Labels: map[string]string{
+			"alertname": rule.Name,
+			"exchange":  exchangeName,
+			"category":  rule.Category,
+			"severity":  rule.Severity,
+		},
+		Annotations: map[string]string{
+			"summary":     fmt.Sprintf("%s: %s %s", rule.Name, exchangeName, rule.Condition),
+			"description": fmt.Sprintf("Condition %q held for exchange %q, category %q.", rule.Condition, exchangeName, rule.Category),
+		},
+		StartsAt: startsAt.Format(time.RFC3339),
+	}
+	if resolved {
+		alert.EndsAt = timeNow().Format(time.RFC3339)
+	}
+
+	body, err := json.Marshal([]amAlert{alert})
+	if err != nil {
+		return
+	}
+
+	resp, err := m.httpClient.Post(m.webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
+
+// timeNow exists so it can be swapped in tests without reaching for a
+// mocking framework.
+var timeNow = time.Now
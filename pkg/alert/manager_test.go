@@ -0,0 +1,123 @@
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSource is a RatingSource backed by an in-memory map, mutated by tests
+// between calls to Manager.Evaluate/EvaluateAll.
+type fakeSource struct {
+	mu       sync.Mutex
+	ratings  map[string][]int
+	exchange string
+}
+
+func (f *fakeSource) GetAllExchanges() []string {
+	return []string{f.exchange}
+}
+
+func (f *fakeSource) CategoryRatings(exchangeName, category string) ([]int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.ratings[category], nil
+}
+
+func (f *fakeSource) set(category string, ratings []int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ratings[category] = ratings
+}
+
+// webhookRecorder is a test AlertManager v2 webhook that records every
+// posted alert batch.
+type webhookRecorder struct {
+	mu     sync.Mutex
+	alerts [][]amAlert
+}
+
+func (w *webhookRecorder) handler(wr http.ResponseWriter, r *http.Request) {
+	var batch []amAlert
+	json.NewDecoder(r.Body).Decode(&batch)
+	w.mu.Lock()
+	w.alerts = append(w.alerts, batch)
+	w.mu.Unlock()
+	wr.WriteHeader(http.StatusOK)
+}
+
+func (w *webhookRecorder) len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.alerts)
+}
+
+func (w *webhookRecorder) last() amAlert {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.alerts[len(w.alerts)-1][0]
+}
+
+// TestManagerFireResendResolve walks a rule through its full state machine:
+// not satisfied -> satisfied but not yet "for" long enough -> firing ->
+// resent on every subsequent satisfied evaluation -> resolved once the
+// condition clears.
+func TestManagerFireResendResolve(t *testing.T) {
+	recorder := &webhookRecorder{}
+	server := httptest.NewServer(http.HandlerFunc(recorder.handler))
+	defer server.Close()
+
+	source := &fakeSource{exchange: "nile", ratings: map[string][]int{"reliability": {4, 4, 4}}}
+	rule := Rule{Name: "low-reliability", Exchange: "nile", Category: "reliability", Condition: "avg() < 2", For: time.Minute}
+	manager := NewManager([]Rule{rule}, source, server.URL)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	restoreTimeNow := timeNow
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = restoreTimeNow }()
+
+	manager.EvaluateAll()
+	if recorder.len() != 0 {
+		t.Fatalf("posted %d alerts while condition unsatisfied, want 0", recorder.len())
+	}
+
+	source.set("reliability", []int{1, 1, 1})
+	manager.EvaluateAll()
+	if recorder.len() != 0 {
+		t.Fatalf("posted %d alerts before the \"for\" duration elapsed, want 0", recorder.len())
+	}
+
+	now = now.Add(2 * time.Minute)
+	manager.EvaluateAll()
+	if recorder.len() != 1 {
+		t.Fatalf("posted %d alerts, want 1 (should have fired)", recorder.len())
+	}
+	if fired := recorder.last(); fired.EndsAt != "" {
+		t.Fatalf("first alert has EndsAt = %q, want unset (firing, not resolved)", fired.EndsAt)
+	}
+
+	now = now.Add(time.Minute)
+	manager.EvaluateAll()
+	if recorder.len() != 2 {
+		t.Fatalf("posted %d alerts, want 2 (should resend while still firing)", recorder.len())
+	}
+
+	source.set("reliability", []int{4, 4, 4})
+	manager.EvaluateAll()
+	if recorder.len() != 3 {
+		t.Fatalf("posted %d alerts, want 3 (should resolve)", recorder.len())
+	}
+	if resolved := recorder.last(); resolved.EndsAt == "" {
+		t.Fatal("resolved alert has no EndsAt set")
+	}
+
+	manager.EvaluateAll()
+	if recorder.len() != 3 {
+		t.Fatalf("posted %d alerts after already resolved, want 3 (no repeat resolve)", recorder.len())
+	}
+}
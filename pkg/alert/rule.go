@@ -0,0 +1,89 @@
+// Package alert implements rule-based threshold alerting over LBTAS
+// ratings, with firing state posted to a Prometheus AlertManager-compatible
+// webhook.
+//
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule declares a single threshold condition evaluated against one
+// exchange/category's rating history.
+type Rule struct {
+	Name      string        `yaml:"name" json:"name"`
+	Exchange  string        `yaml:"exchange" json:"exchange"`
+	Category  string        `yaml:"category" json:"category"`
+	Condition string        `yaml:"condition" json:"condition"`
+	For       time.Duration `yaml:"for" json:"for"`
+	Severity  string        `yaml:"severity" json:"severity"`
+}
+
+func (r Rule) key() string {
+	return r.Name + "/" + r.Exchange
+}
+
+// UnmarshalJSON accepts the same human-readable duration syntax as YAML's
+// "for: 24h" for JSON rule files, since encoding/json has no built-in
+// support for time.Duration.
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	type plain Rule
+	aux := struct {
+		For string `json:"for"`
+		*plain
+	}{
+		plain: (*plain)(r),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.For != "" {
+		d, err := time.ParseDuration(aux.For)
+		if err != nil {
+			return fmt.Errorf("alert: invalid \"for\" duration %q: %w", aux.For, err)
+		}
+		r.For = d
+	}
+
+	return nil
+}
+
+// LoadRules reads a set of rules from a YAML or JSON file, chosen by the
+// file extension.
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("alert: reading rules file: %w", err)
+	}
+
+	var rules []Rule
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &rules)
+	default:
+		err = json.Unmarshal(data, &rules)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("alert: parsing rules file: %w", err)
+	}
+
+	for _, rule := range rules {
+		if rule.Name == "" || rule.Exchange == "" || rule.Category == "" || rule.Condition == "" {
+			return nil, fmt.Errorf("alert: rule missing required field: %+v", rule)
+		}
+	}
+
+	return rules, nil
+}
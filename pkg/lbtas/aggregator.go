@@ -0,0 +1,189 @@
+package lbtas
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Aggregator reduces a series of Ratings to a single point estimate. The
+// default is MeanAggregator; the others trade off differently against the
+// scale's -1 ("No Trust") rating and against small sample sizes.
+type Aggregator interface {
+	// Aggregate returns a point estimate over ratings. ok is false when
+	// ratings is empty.
+	Aggregate(ratings []Rating) (value float64, ok bool)
+}
+
+// MeanAggregator is the plain arithmetic mean LBTAS has always used.
+type MeanAggregator struct{}
+
+func (MeanAggregator) Aggregate(ratings []Rating) (float64, bool) {
+	if len(ratings) == 0 {
+		return 0, false
+	}
+	return averageRatings(ratings), true
+}
+
+// SafetyWeightedAggregator scales -1 ("No Trust - User was harmed")
+// ratings by NegativeWeight before averaging, so a handful of harmful
+// interactions can't be washed out by otherwise-fine ones. NegativeWeight
+// defaults to 3 (so -1 counts as -3) when zero.
+type SafetyWeightedAggregator struct {
+	NegativeWeight float64
+}
+
+func (a SafetyWeightedAggregator) Aggregate(ratings []Rating) (float64, bool) {
+	if len(ratings) == 0 {
+		return 0, false
+	}
+
+	weight := a.NegativeWeight
+	if weight == 0 {
+		weight = 3
+	}
+
+	sum := 0.0
+	for _, r := range ratings {
+		v := float64(r.Value)
+		if r.Value < 0 {
+			v *= weight
+		}
+		sum += v
+	}
+	return sum / float64(len(ratings)), true
+}
+
+// TrimmedMeanAggregator drops the top and bottom TrimFraction of ratings
+// (by value) before averaging, to dampen outliers. TrimFraction defaults to
+// 0.1 (10% from each end) when zero.
+type TrimmedMeanAggregator struct {
+	TrimFraction float64
+}
+
+func (a TrimmedMeanAggregator) Aggregate(ratings []Rating) (float64, bool) {
+	if len(ratings) == 0 {
+		return 0, false
+	}
+
+	fraction := a.TrimFraction
+	if fraction == 0 {
+		fraction = 0.1
+	}
+
+	values := make([]int, len(ratings))
+	for i, r := range ratings {
+		values[i] = r.Value
+	}
+	sort.Ints(values)
+
+	trim := int(float64(len(values)) * fraction)
+	trimmed := values[trim : len(values)-trim]
+	if len(trimmed) == 0 {
+		trimmed = values
+	}
+
+	sum := 0
+	for _, v := range trimmed {
+		sum += v
+	}
+	return float64(sum) / float64(len(trimmed)), true
+}
+
+// BayesianAggregator shrinks the sample mean toward PriorMean, weighted by
+// PseudoCount "phantom" prior observations, so an exchange with a couple of
+// ratings doesn't outrank one with hundreds. PriorMean defaults to 1.5 (the
+// midpoint of the -1..4 scale) and PseudoCount to 5 when zero.
+type BayesianAggregator struct {
+	PriorMean   float64
+	PseudoCount float64
+}
+
+func (a BayesianAggregator) Aggregate(ratings []Rating) (float64, bool) {
+	if len(ratings) == 0 {
+		return 0, false
+	}
+
+	priorMean := a.PriorMean
+	if priorMean == 0 {
+		priorMean = 1.5
+	}
+	pseudoCount := a.PseudoCount
+	if pseudoCount == 0 {
+		pseudoCount = 5
+	}
+
+	sum := 0.0
+	for _, r := range ratings {
+		sum += float64(r.Value)
+	}
+
+	return (priorMean*pseudoCount + sum) / (pseudoCount + float64(len(ratings))), true
+}
+
+// RecencyDecayAggregator weights each rating by exp(-Lambda * age_days),
+// so recent ratings dominate older ones. Lambda defaults to 0.05 (a rating
+// from 14 days ago carries about half the weight of one from today) when
+// zero.
+type RecencyDecayAggregator struct {
+	Lambda float64
+}
+
+func (a RecencyDecayAggregator) Aggregate(ratings []Rating) (float64, bool) {
+	if len(ratings) == 0 {
+		return 0, false
+	}
+
+	lambda := a.Lambda
+	if lambda == 0 {
+		lambda = 0.05
+	}
+
+	now := time.Now()
+	var weightedSum, weightTotal float64
+	for _, r := range ratings {
+		ageDays := now.Sub(r.At).Hours() / 24
+		if ageDays < 0 {
+			ageDays = 0
+		}
+		weight := math.Exp(-lambda * ageDays)
+		weightedSum += weight * float64(r.Value)
+		weightTotal += weight
+	}
+
+	if weightTotal == 0 {
+		return averageRatings(ratings), true
+	}
+	return weightedSum / weightTotal, true
+}
+
+// NewAggregator builds the Aggregator named by the `--aggregator` flag,
+// using each implementation's defaults.
+func NewAggregator(name string) (Aggregator, error) {
+	switch name {
+	case "", "mean":
+		return MeanAggregator{}, nil
+	case "safety_weighted":
+		return SafetyWeightedAggregator{}, nil
+	case "trimmed_mean":
+		return TrimmedMeanAggregator{}, nil
+	case "bayesian":
+		return BayesianAggregator{}, nil
+	case "recency_decay":
+		return RecencyDecayAggregator{}, nil
+	default:
+		return nil, fmt.Errorf("lbtas: unknown aggregator %q", name)
+	}
+}
+
+func averageRatings(ratings []Rating) float64 {
+	if len(ratings) == 0 {
+		return 0
+	}
+	sum := 0
+	for _, r := range ratings {
+		sum += r.Value
+	}
+	return float64(sum) / float64(len(ratings))
+}
@@ -0,0 +1,163 @@
+package lbtas
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func ratingsOf(values ...int) []Rating {
+	ratings := make([]Rating, len(values))
+	for i, v := range values {
+		ratings[i] = Rating{Value: v}
+	}
+	return ratings
+}
+
+func closeEnough(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestMeanAggregator(t *testing.T) {
+	if _, ok := (MeanAggregator{}).Aggregate(nil); ok {
+		t.Fatalf("Aggregate(nil) ok = true, want false")
+	}
+
+	got, ok := (MeanAggregator{}).Aggregate(ratingsOf(4, 3, 2, -1))
+	if !ok {
+		t.Fatalf("Aggregate() ok = false, want true")
+	}
+	if want := 2.0; !closeEnough(got, want) {
+		t.Errorf("Aggregate() = %v, want %v", got, want)
+	}
+}
+
+func TestSafetyWeightedAggregator(t *testing.T) {
+	got, ok := (SafetyWeightedAggregator{}).Aggregate(ratingsOf(4, -1))
+	if !ok {
+		t.Fatalf("Aggregate() ok = false, want true")
+	}
+	// default weight 3: (4 + -1*3) / 2 = 0.5
+	if want := 0.5; !closeEnough(got, want) {
+		t.Errorf("Aggregate() with default weight = %v, want %v", got, want)
+	}
+
+	got, ok = (SafetyWeightedAggregator{NegativeWeight: 5}).Aggregate(ratingsOf(4, -1))
+	if !ok {
+		t.Fatalf("Aggregate() ok = false, want true")
+	}
+	// (4 + -1*5) / 2 = -0.5
+	if want := -0.5; !closeEnough(got, want) {
+		t.Errorf("Aggregate() with explicit weight = %v, want %v", got, want)
+	}
+}
+
+func TestTrimmedMeanAggregator(t *testing.T) {
+	// 10 values, default 10% trim drops one from each end: 1..8 -> avg 4.5
+	got, ok := (TrimmedMeanAggregator{}).Aggregate(ratingsOf(0, 1, 2, 3, 4, 5, 6, 7, 8, 9))
+	if !ok {
+		t.Fatalf("Aggregate() ok = false, want true")
+	}
+	if want := 4.5; !closeEnough(got, want) {
+		t.Errorf("Aggregate() with default trim = %v, want %v", got, want)
+	}
+
+	// Too few ratings for the trim fraction to remove anything leaves the
+	// plain mean.
+	got, ok = (TrimmedMeanAggregator{}).Aggregate(ratingsOf(1, 2, 3))
+	if !ok {
+		t.Fatalf("Aggregate() ok = false, want true")
+	}
+	if want := 2.0; !closeEnough(got, want) {
+		t.Errorf("Aggregate() with untrimmable sample = %v, want %v", got, want)
+	}
+
+	got, ok = (TrimmedMeanAggregator{TrimFraction: 0.2}).Aggregate(ratingsOf(0, 1, 2, 3, 4, 5, 6, 7, 8, 9))
+	if !ok {
+		t.Fatalf("Aggregate() ok = false, want true")
+	}
+	// 20% trim drops two from each end: 2..7 -> avg 4.5
+	if want := 4.5; !closeEnough(got, want) {
+		t.Errorf("Aggregate() with explicit trim = %v, want %v", got, want)
+	}
+}
+
+func TestBayesianAggregator(t *testing.T) {
+	// default prior 1.5, pseudo-count 5: (1.5*5 + 4) / (5+1) = 1.9166...
+	got, ok := (BayesianAggregator{}).Aggregate(ratingsOf(4))
+	if !ok {
+		t.Fatalf("Aggregate() ok = false, want true")
+	}
+	if want := (1.5*5 + 4) / 6; !closeEnough(got, want) {
+		t.Errorf("Aggregate() with default prior = %v, want %v", got, want)
+	}
+
+	got, ok = (BayesianAggregator{PriorMean: 2, PseudoCount: 1}).Aggregate(ratingsOf(4, 4, 4, 4, 4, 4, 4, 4, 4, 4))
+	if !ok {
+		t.Fatalf("Aggregate() ok = false, want true")
+	}
+	// a small pseudo-count barely shrinks a large, unanimous sample toward
+	// the prior, so the result should sit close to the raw mean (4).
+	if want := (2*1 + 40) / (1 + 10.0); !closeEnough(got, want) {
+		t.Errorf("Aggregate() with large sample = %v, want %v", got, want)
+	}
+}
+
+func TestRecencyDecayAggregator(t *testing.T) {
+	now := time.Now()
+	ratings := []Rating{
+		{Value: 0, At: now.Add(-30 * 24 * time.Hour)},
+		{Value: 4, At: now},
+	}
+
+	got, ok := (RecencyDecayAggregator{}).Aggregate(ratings)
+	if !ok {
+		t.Fatalf("Aggregate() ok = false, want true")
+	}
+	// the 30-day-old rating is down-weighted, so the result should sit
+	// closer to the recent rating (4) than the plain mean (2).
+	if got <= 2.0 || got >= 4.0 {
+		t.Errorf("Aggregate() = %v, want strictly between the plain mean and the recent rating", got)
+	}
+
+	// all-zero-age ratings fall back to the plain mean.
+	allNow := []Rating{{Value: 0, At: now}, {Value: 4, At: now}}
+	got, ok = (RecencyDecayAggregator{}).Aggregate(allNow)
+	if !ok {
+		t.Fatalf("Aggregate() ok = false, want true")
+	}
+	if want := 2.0; !closeEnough(got, want) {
+		t.Errorf("Aggregate() with equal ages = %v, want %v", got, want)
+	}
+}
+
+func TestNewAggregator(t *testing.T) {
+	cases := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"", false},
+		{"mean", false},
+		{"safety_weighted", false},
+		{"trimmed_mean", false},
+		{"bayesian", false},
+		{"recency_decay", false},
+		{"bogus", true},
+	}
+
+	for _, tc := range cases {
+		agg, err := NewAggregator(tc.name)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("NewAggregator(%q) error = nil, want error", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NewAggregator(%q) unexpected error: %v", tc.name, err)
+		}
+		if agg == nil {
+			t.Errorf("NewAggregator(%q) = nil, want non-nil Aggregator", tc.name)
+		}
+	}
+}
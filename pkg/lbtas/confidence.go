@@ -0,0 +1,82 @@
+package lbtas
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// MinSamplesForConfidence is the minimum number of ratings a category needs
+// before its CategorySummary is marked Sufficient, and the minimum total
+// number of ratings (across all categories in the window) an exchange needs
+// to appear in the top/bottom performer lists.
+const MinSamplesForConfidence = 5
+
+const bootstrapIterations = 1000
+
+// CategorySummary is a single category's aggregate, with a bootstrap
+// confidence interval around it. Callers should check Sufficient before
+// trusting Average for ranking - with too few ratings, Low and High span
+// most of the rating scale.
+type CategorySummary struct {
+	Average    float64 `json:"average"`
+	Low        float64 `json:"ci_low"`
+	High       float64 `json:"ci_high"`
+	Count      int     `json:"count"`
+	Sufficient bool    `json:"sufficient"`
+}
+
+// summarize reduces ratings to a CategorySummary using agg, with a bootstrap
+// confidence interval. It returns nil if ratings is empty.
+func summarize(ratings []Rating, agg Aggregator) *CategorySummary {
+	if len(ratings) == 0 {
+		return nil
+	}
+
+	avg, ok := agg.Aggregate(ratings)
+	if !ok {
+		return nil
+	}
+
+	low, high := bootstrapCI(ratings, agg)
+
+	return &CategorySummary{
+		Average:    avg,
+		Low:        low,
+		High:       high,
+		Count:      len(ratings),
+		Sufficient: len(ratings) >= MinSamplesForConfidence,
+	}
+}
+
+// bootstrapCI estimates a 95% confidence interval for agg.Aggregate(ratings)
+// by resampling ratings with replacement bootstrapIterations times and
+// taking the 2.5th/97.5th percentiles of the resulting point estimates.
+func bootstrapCI(ratings []Rating, agg Aggregator) (low, high float64) {
+	n := len(ratings)
+	if n == 0 {
+		return 0, 0
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	estimates := make([]float64, bootstrapIterations)
+	resample := make([]Rating, n)
+
+	for i := 0; i < bootstrapIterations; i++ {
+		for j := range resample {
+			resample[j] = ratings[rng.Intn(n)]
+		}
+		value, _ := agg.Aggregate(resample)
+		estimates[i] = value
+	}
+
+	sort.Float64s(estimates)
+
+	lowIdx := int(0.025 * float64(bootstrapIterations))
+	highIdx := int(0.975 * float64(bootstrapIterations))
+	if highIdx >= bootstrapIterations {
+		highIdx = bootstrapIterations - 1
+	}
+
+	return estimates[lowIdx], estimates[highIdx]
+}
@@ -0,0 +1,24 @@
+package lbtas
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration parses the same syntax as time.ParseDuration, plus a
+// trailing "d" unit for days (e.g. "30d"), which callers like
+// `lbtas summarize --window 30d` need but the standard library doesn't
+// support.
+func ParseDuration(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.ParseFloat(strings.TrimSuffix(s, "d"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("lbtas: invalid duration %q: %w", s, err)
+		}
+		return time.Duration(days * 24 * float64(time.Hour)), nil
+	}
+
+	return time.ParseDuration(s)
+}
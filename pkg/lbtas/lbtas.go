@@ -0,0 +1,601 @@
+// Package lbtas implements the Leveson-Based Trade Assessment Scale (LBTAS),
+// a rating system for digital commerce based on Nancy Leveson's aircraft
+// software assessment methodology.
+//
+// Copyright (C) 2024 Network Theory Applied Research Institute
+// Licensed under GNU Affero General Public License v3.0
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+package lbtas
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	Version = "1.0.0"
+	Author  = "Network Theory Applied Research Institute"
+	License = "AGPL-3.0"
+)
+
+var DefaultCategories = []string{"reliability", "usability", "performance", "support"}
+
+var RatingDescriptions = map[int]string{
+	-1: "No Trust - User was harmed, exploited, or received a product or service with no discipline or malicious intent.",
+	0:  "Cynical Satisfaction - Interaction fulfills a basic promise requiring little to no discipline toward user satisfaction.",
+	1:  "Basic Promise - Interaction meets all articulated user demands, no more.",
+	2:  "Basic Satisfaction - Interaction meets socially acceptable standards exceeding articulated user demands.",
+	3:  "No Negative Consequences - Interaction designed to prevent loss, exceed basic quality.",
+	4:  "Delight - Interaction anticipates the evolution of user practices and concerns post-transaction.",
+}
+
+type Metadata struct {
+	Created      string `json:"created"`
+	TotalRatings int    `json:"total_ratings"`
+}
+
+// Rating is a single rating event: a value plus who gave it, when, and any
+// free-text note.
+type Rating struct {
+	Value int       `json:"value"`
+	At    time.Time `json:"at"`
+	Rater string    `json:"rater,omitempty"`
+	Note  string    `json:"note,omitempty"`
+}
+
+// RatingList is a time-ordered series of Ratings for one exchange/category
+// pair. Its JSON form also accepts the earlier storage format, a plain
+// array of ints, and migrates it transparently on load: migrated entries
+// have a zero At, so they're excluded by any windowed query with a
+// non-zero From.
+type RatingList []Rating
+
+func (rl *RatingList) UnmarshalJSON(data []byte) error {
+	var ratings []Rating
+	if err := json.Unmarshal(data, &ratings); err == nil {
+		*rl = ratings
+		return nil
+	}
+
+	var legacy []int
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return fmt.Errorf("lbtas: unrecognized rating list format: %w", err)
+	}
+
+	migrated := make(RatingList, len(legacy))
+	for i, value := range legacy {
+		migrated[i] = Rating{Value: value}
+	}
+	*rl = migrated
+	return nil
+}
+
+// Values returns the plain rating values, in order.
+func (rl RatingList) Values() []int {
+	out := make([]int, len(rl))
+	for i, r := range rl {
+		out[i] = r.Value
+	}
+	return out
+}
+
+// InWindow returns the ratings whose timestamp falls within w.
+func (rl RatingList) InWindow(w TimeWindow) []Rating {
+	var out []Rating
+	for _, r := range rl {
+		if w.Includes(r.At) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// TimeWindow bounds a query by time; a zero From or To is unbounded on
+// that side, so the zero TimeWindow matches everything (lifetime).
+type TimeWindow struct {
+	From time.Time
+	To   time.Time
+}
+
+func (w TimeWindow) Includes(t time.Time) bool {
+	if !w.From.IsZero() && t.Before(w.From) {
+		return false
+	}
+	if !w.To.IsZero() && t.After(w.To) {
+		return false
+	}
+	return true
+}
+
+type ExchangeData struct {
+	Reliability RatingList `json:"reliability"`
+	Usability   RatingList `json:"usability"`
+	Performance RatingList `json:"performance"`
+	Support     RatingList `json:"support"`
+	Metadata    Metadata   `json:"_metadata"`
+}
+
+// categoryLists maps category name to its RatingList, the shared shape
+// every per-category loop in this file iterates over.
+func categoryLists(exchange ExchangeData) map[string]RatingList {
+	return map[string]RatingList{
+		"reliability": exchange.Reliability,
+		"usability":   exchange.Usability,
+		"performance": exchange.Performance,
+		"support":     exchange.Support,
+	}
+}
+
+type StorageData map[string]ExchangeData
+
+// RatingSummary maps category name to its CategorySummary. A nil entry
+// means the category has no ratings at all; check Sufficient on a non-nil
+// entry before trusting Average for ranking.
+type RatingSummary map[string]*CategorySummary
+
+type SystemReport struct {
+	TotalExchanges   int                   `json:"total_exchanges"`
+	TotalRatings     int                   `json:"total_ratings"`
+	SystemAverage    *float64              `json:"system_average"`
+	CategoryAverages map[string]*float64   `json:"category_averages"`
+	TopPerformers    []ExchangePerformance `json:"top_performers"`
+	BottomPerformers []ExchangePerformance `json:"bottom_performers"`
+}
+
+// ExchangePerformance is one exchange's overall average in a top/bottom
+// performer list. Only exchanges with at least MinSamplesForConfidence
+// ratings are included, so a single outlier rating can't rank an exchange
+// above ones with real sample sizes.
+type ExchangePerformance struct {
+	Name    string
+	Average float64
+	Count   int
+}
+
+// RatingAddedFunc is invoked after a rating is successfully recorded. It is
+// used by callers such as the `serve` subcommand to feed a Prometheus
+// counter without coupling this package to any particular metrics backend.
+type RatingAddedFunc func(exchangeName, criterion string, rating int)
+
+// LevesonRatingSystem is the reusable core of LBTAS: it owns the rating/
+// reporting logic shared by every subcommand, backed by a pluggable Store.
+type LevesonRatingSystem struct {
+	categories []string
+	store      Store
+	onRating   []RatingAddedFunc
+	aggregator Aggregator
+}
+
+// Option configures optional LevesonRatingSystem behavior at construction
+// time, such as the Aggregator used to compute averages.
+type Option func(*LevesonRatingSystem)
+
+// WithAggregator overrides the default MeanAggregator used by ViewRatings
+// and GenerateReport.
+func WithAggregator(agg Aggregator) Option {
+	return func(lrs *LevesonRatingSystem) {
+		lrs.aggregator = agg
+	}
+}
+
+// OnRatingAdded registers a callback to run after every successful
+// AddRating call.
+func (lrs *LevesonRatingSystem) OnRatingAdded(fn RatingAddedFunc) {
+	lrs.onRating = append(lrs.onRating, fn)
+}
+
+// New constructs a LevesonRatingSystem backed by a FileStore at
+// storagePath. An empty storagePath means in-memory only, which is useful
+// for tests. A nil or empty categories slice falls back to
+// DefaultCategories.
+func New(storagePath string, categories []string, opts ...Option) *LevesonRatingSystem {
+	var store Store
+	if storagePath != "" {
+		store = NewFileStore(storagePath)
+	} else {
+		store = newMemStore()
+	}
+
+	return NewWithStore(store, categories, opts...)
+}
+
+// NewWithStore constructs a LevesonRatingSystem backed by an arbitrary
+// Store, letting callers swap in a different persistence backend without
+// changing any rating/reporting logic.
+func NewWithStore(store Store, categories []string, opts ...Option) *LevesonRatingSystem {
+	if len(categories) == 0 {
+		categories = make([]string, len(DefaultCategories))
+		copy(categories, DefaultCategories)
+	}
+
+	lrs := &LevesonRatingSystem{
+		categories: categories,
+		store:      store,
+		aggregator: MeanAggregator{},
+	}
+
+	for _, opt := range opts {
+		opt(lrs)
+	}
+
+	return lrs
+}
+
+func (lrs *LevesonRatingSystem) Categories() []string {
+	return lrs.categories
+}
+
+func (lrs *LevesonRatingSystem) AddExchange(name string) error {
+	return lrs.store.Update(func(data StorageData) (StorageData, error) {
+		if _, exists := data[name]; exists {
+			return nil, fmt.Errorf("exchange '%s' already exists", name)
+		}
+
+		data[name] = ExchangeData{
+			Reliability: RatingList{},
+			Usability:   RatingList{},
+			Performance: RatingList{},
+			Support:     RatingList{},
+			Metadata: Metadata{
+				Created:      time.Now().Format(time.RFC3339),
+				TotalRatings: 0,
+			},
+		}
+
+		return data, nil
+	})
+}
+
+// AddRating records a rating with no rater or note attached.
+func (lrs *LevesonRatingSystem) AddRating(exchangeName, criterion string, rating int) error {
+	return lrs.addRating(exchangeName, criterion, rating, "", "")
+}
+
+// AddRatingWithDetails records a rating attributing it to rater and
+// attaching a free-text note, for callers (like `lbtas record`) that
+// capture more than the bare value.
+func (lrs *LevesonRatingSystem) AddRatingWithDetails(exchangeName, criterion string, rating int, rater, note string) error {
+	return lrs.addRating(exchangeName, criterion, rating, rater, note)
+}
+
+func (lrs *LevesonRatingSystem) addRating(exchangeName, criterion string, rating int, rater, note string) error {
+	if !Contains(lrs.categories, criterion) {
+		return fmt.Errorf("criterion '%s' not in valid categories: %v", criterion, lrs.categories)
+	}
+
+	if rating < -1 || rating > 4 {
+		return fmt.Errorf("rating must be between -1 and 4, got %d", rating)
+	}
+
+	entry := Rating{Value: rating, At: time.Now(), Rater: rater, Note: note}
+
+	err := lrs.store.Update(func(data StorageData) (StorageData, error) {
+		exchange, exists := data[exchangeName]
+		if !exists {
+			return nil, fmt.Errorf("exchange '%s' does not exist", exchangeName)
+		}
+
+		switch criterion {
+		case "reliability":
+			exchange.Reliability = append(exchange.Reliability, entry)
+		case "usability":
+			exchange.Usability = append(exchange.Usability, entry)
+		case "performance":
+			exchange.Performance = append(exchange.Performance, entry)
+		case "support":
+			exchange.Support = append(exchange.Support, entry)
+		}
+
+		exchange.Metadata.TotalRatings++
+		data[exchangeName] = exchange
+
+		return data, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, fn := range lrs.onRating {
+		fn(exchangeName, criterion, rating)
+	}
+
+	return nil
+}
+
+func (lrs *LevesonRatingSystem) ViewRatings(name string) (RatingSummary, error) {
+	data, err := lrs.store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	exchange, exists := data[name]
+	if !exists {
+		return nil, fmt.Errorf("exchange '%s' does not exist", name)
+	}
+
+	summary := make(RatingSummary)
+	lists := categoryLists(exchange)
+
+	for _, criterion := range lrs.categories {
+		summary[criterion] = summarize(lists[criterion], lrs.aggregator)
+	}
+
+	return summary, nil
+}
+
+// CategoryAverages returns the point-estimate average for each category of
+// an exchange, without the bootstrap confidence interval ViewRatings
+// computes. Use this for hot paths like a metrics scrape that only read
+// Average and would otherwise pay for a CI that's thrown away.
+func (lrs *LevesonRatingSystem) CategoryAverages(name string) (map[string]float64, error) {
+	data, err := lrs.store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	exchange, exists := data[name]
+	if !exists {
+		return nil, fmt.Errorf("exchange '%s' does not exist", name)
+	}
+
+	averages := make(map[string]float64)
+	lists := categoryLists(exchange)
+
+	for _, criterion := range lrs.categories {
+		ratings := lists[criterion]
+		if len(ratings) == 0 {
+			continue
+		}
+		if avg, ok := lrs.aggregator.Aggregate(ratings); ok {
+			averages[criterion] = avg
+		}
+	}
+
+	return averages, nil
+}
+
+// CategoryRatings returns the raw rating values recorded for an exchange in
+// a single category, in the order they were added. It is used by consumers
+// like the alerting subsystem that need more than the plain average.
+func (lrs *LevesonRatingSystem) CategoryRatings(exchangeName, category string) ([]int, error) {
+	data, err := lrs.store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	exchange, exists := data[exchangeName]
+	if !exists {
+		return nil, fmt.Errorf("exchange '%s' does not exist", exchangeName)
+	}
+
+	lists := categoryLists(exchange)
+	list, ok := lists[category]
+	if !ok {
+		return nil, fmt.Errorf("criterion '%s' not in valid categories: %v", category, lrs.categories)
+	}
+
+	return list.Values(), nil
+}
+
+func (lrs *LevesonRatingSystem) GetAllExchanges() []string {
+	data, err := lrs.store.Load()
+	if err != nil {
+		return nil
+	}
+
+	exchanges := make([]string, 0, len(data))
+	for name := range data {
+		exchanges = append(exchanges, name)
+	}
+	sort.Strings(exchanges)
+	return exchanges
+}
+
+// GenerateReport summarizes the system, restricted to ratings whose
+// timestamp falls within window, using the Aggregator configured via
+// WithAggregator (MeanAggregator by default). Pass the zero TimeWindow for
+// a lifetime report.
+func (lrs *LevesonRatingSystem) GenerateReport(window TimeWindow) SystemReport {
+	return lrs.GenerateReportWith(window, lrs.aggregator)
+}
+
+// GenerateReportWith is GenerateReport with an explicit Aggregator,
+// overriding the one configured at construction time. It's used by callers
+// like the `serve` subcommand's HTTP report endpoint that let a caller pick
+// the aggregator per request.
+func (lrs *LevesonRatingSystem) GenerateReportWith(window TimeWindow, agg Aggregator) SystemReport {
+	data, err := lrs.store.Load()
+	if err != nil {
+		return Report(StorageData{}, lrs.categories, window, agg)
+	}
+	return Report(data, lrs.categories, window, agg)
+}
+
+// Report computes a SystemReport over an arbitrary StorageData snapshot,
+// such as one reconstructed by Replay. GenerateReport is a thin wrapper
+// around this for the system's own live storage.
+func Report(exchanges StorageData, categories []string, window TimeWindow, agg Aggregator) SystemReport {
+	totalExchanges := len(exchanges)
+
+	if totalExchanges == 0 {
+		return SystemReport{
+			TotalExchanges:   0,
+			TotalRatings:     0,
+			SystemAverage:    nil,
+			CategoryAverages: make(map[string]*float64),
+			TopPerformers:    []ExchangePerformance{},
+			BottomPerformers: []ExchangePerformance{},
+		}
+	}
+
+	var allRatings []Rating
+	categoryTotals := make(map[string][]Rating)
+	for _, category := range categories {
+		categoryTotals[category] = []Rating{}
+	}
+
+	exchangeAverages := make(map[string]float64)
+	exchangeCounts := make(map[string]int)
+
+	for exchangeName, exchangeData := range exchanges {
+		var exchangeRatings []float64
+		lists := categoryLists(exchangeData)
+
+		for _, category := range categories {
+			values := lists[category].InWindow(window)
+			if len(values) > 0 {
+				avg, ok := agg.Aggregate(values)
+				if ok {
+					exchangeRatings = append(exchangeRatings, avg)
+				}
+				categoryTotals[category] = append(categoryTotals[category], values...)
+				allRatings = append(allRatings, values...)
+				exchangeCounts[exchangeName] += len(values)
+			}
+		}
+
+		if len(exchangeRatings) > 0 {
+			exchangeAverages[exchangeName] = averageFloat(exchangeRatings)
+		}
+	}
+
+	var systemAverage *float64
+	if len(allRatings) > 0 {
+		avg, ok := agg.Aggregate(allRatings)
+		if ok {
+			systemAverage = &avg
+		}
+	}
+
+	categoryAverages := make(map[string]*float64)
+	for category, values := range categoryTotals {
+		if avg, ok := agg.Aggregate(values); ok {
+			categoryAverages[category] = &avg
+		} else {
+			categoryAverages[category] = nil
+		}
+	}
+
+	performances := make([]ExchangePerformance, 0, len(exchangeAverages))
+	for name, avg := range exchangeAverages {
+		if exchangeCounts[name] < MinSamplesForConfidence {
+			continue
+		}
+		performances = append(performances, ExchangePerformance{Name: name, Average: avg, Count: exchangeCounts[name]})
+	}
+
+	sort.Slice(performances, func(i, j int) bool {
+		return performances[i].Average > performances[j].Average
+	})
+
+	topPerformers := performances
+	if len(topPerformers) > 5 {
+		topPerformers = topPerformers[:5]
+	}
+
+	bottomPerformers := make([]ExchangePerformance, 0)
+	if len(performances) > 0 {
+		start := len(performances) - 5
+		if start < 0 {
+			start = 0
+		}
+		bottomPerformers = performances[start:]
+		for i, j := 0, len(bottomPerformers)-1; i < j; i, j = i+1, j-1 {
+			bottomPerformers[i], bottomPerformers[j] = bottomPerformers[j], bottomPerformers[i]
+		}
+	}
+
+	return SystemReport{
+		TotalExchanges:   totalExchanges,
+		TotalRatings:     len(allRatings),
+		SystemAverage:    systemAverage,
+		CategoryAverages: categoryAverages,
+		TopPerformers:    topPerformers,
+		BottomPerformers: bottomPerformers,
+	}
+}
+
+func (lrs *LevesonRatingSystem) ExportToJSON(outputPath string) error {
+	exchanges, err := lrs.store.Load()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(exchanges, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputPath, data, 0644)
+}
+
+func (lrs *LevesonRatingSystem) ExportToCSV(outputPath string) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return lrs.WriteCSV(file)
+}
+
+// WriteCSV writes the same rows as ExportToCSV directly to w, so callers
+// like the HTTP `/export.csv` endpoint don't need a temporary file.
+func (lrs *LevesonRatingSystem) WriteCSV(w io.Writer) error {
+	exchanges, err := lrs.store.Load()
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	writer.Write([]string{"exchange", "category", "rating", "at", "index"})
+
+	for exchangeName, exchangeData := range exchanges {
+		lists := categoryLists(exchangeData)
+
+		for _, category := range lrs.categories {
+			for i, rating := range lists[category] {
+				writer.Write([]string{
+					exchangeName,
+					category,
+					strconv.Itoa(rating.Value),
+					rating.At.Format(time.RFC3339),
+					strconv.Itoa(i + 1),
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+func averageFloat(numbers []float64) float64 {
+	if len(numbers) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, n := range numbers {
+		sum += n
+	}
+	return sum / float64(len(numbers))
+}
+
+func Contains(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+package lbtas
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Event is a single rating event as it appears in a Recorder's append-only
+// log: enough to both replay past state and audit who rated what, when.
+type Event struct {
+	Exchange  string    `json:"exchange"`
+	Criterion string    `json:"criterion"`
+	Rating    int       `json:"rating"`
+	Rater     string    `json:"rater,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	At        time.Time `json:"at"`
+}
+
+// Recorder appends Events to a JSONL log, fsyncing after every write so a
+// crash can never lose an acknowledged rating.
+type Recorder struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewRecorder(path string) *Recorder {
+	return &Recorder{path: path}
+}
+
+func (r *Recorder) Append(event Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	if _, err := f.Write(line); err != nil {
+		return err
+	}
+
+	return f.Sync()
+}
+
+// ReadEvents loads every event from a Recorder's JSONL log, in the order
+// they were appended. A log that doesn't exist yet - no `lbtas record` call
+// has happened - is treated as empty rather than an error.
+func ReadEvents(path string) ([]Event, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var events []Event
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, scanner.Err()
+}
+
+// Replay reconstructs a StorageData snapshot from a recorded event log,
+// including only events whose timestamp falls within window - letting
+// `lbtas replay --to T` answer "what did the system look like at T".
+func Replay(events []Event, window TimeWindow) StorageData {
+	data := make(StorageData)
+
+	for _, event := range events {
+		if !window.Includes(event.At) {
+			continue
+		}
+
+		exchange, ok := data[event.Exchange]
+		if !ok {
+			exchange = ExchangeData{Metadata: Metadata{Created: event.At.Format(time.RFC3339)}}
+		}
+
+		rating := Rating{Value: event.Rating, At: event.At, Rater: event.Rater, Note: event.Note}
+		switch event.Criterion {
+		case "reliability":
+			exchange.Reliability = append(exchange.Reliability, rating)
+		case "usability":
+			exchange.Usability = append(exchange.Usability, rating)
+		case "performance":
+			exchange.Performance = append(exchange.Performance, rating)
+		case "support":
+			exchange.Support = append(exchange.Support, rating)
+		}
+
+		exchange.Metadata.TotalRatings++
+		data[event.Exchange] = exchange
+	}
+
+	return data
+}
+
+// BucketStat is one time bucket's aggregate for a single exchange/category
+// series.
+type BucketStat struct {
+	BucketStart time.Time `json:"bucket_start"`
+	Average     float64   `json:"average"`
+	StdDev      float64   `json:"std_dev"`
+	Count       int       `json:"count"`
+}
+
+// SeriesSummary is the rolling-bucket summary for one exchange/category
+// pair, as produced by Summarize.
+type SeriesSummary struct {
+	Exchange   string       `json:"exchange"`
+	Category   string       `json:"category"`
+	Buckets    []BucketStat `json:"buckets"`
+	TrendDelta float64      `json:"trend_delta"`
+}
+
+// Summarize buckets a recorded event log into fixed-width time windows and
+// computes per-bucket average and standard deviation, plus an overall trend
+// delta (last non-empty bucket average minus first), for every
+// exchange/category pair that has at least one event in window.
+func Summarize(events []Event, categories []string, window TimeWindow, bucket time.Duration) []SeriesSummary {
+	type key struct{ exchange, category string }
+	grouped := make(map[key][]Event)
+
+	for _, event := range events {
+		if !window.Includes(event.At) {
+			continue
+		}
+		if !Contains(categories, event.Criterion) {
+			continue
+		}
+		k := key{event.Exchange, event.Criterion}
+		grouped[k] = append(grouped[k], event)
+	}
+
+	origin := window.From
+
+	summaries := make([]SeriesSummary, 0, len(grouped))
+	for k, evs := range grouped {
+		sort.Slice(evs, func(i, j int) bool { return evs[i].At.Before(evs[j].At) })
+
+		start := origin
+		if start.IsZero() {
+			start = evs[0].At
+		}
+
+		buckets := make(map[int][]int)
+		for _, e := range evs {
+			idx := int(e.At.Sub(start) / bucket)
+			buckets[idx] = append(buckets[idx], e.Rating)
+		}
+
+		indices := make([]int, 0, len(buckets))
+		for idx := range buckets {
+			indices = append(indices, idx)
+		}
+		sort.Ints(indices)
+
+		stats := make([]BucketStat, 0, len(indices))
+		for _, idx := range indices {
+			values := buckets[idx]
+			avg := averageInts(values)
+			stats = append(stats, BucketStat{
+				BucketStart: start.Add(time.Duration(idx) * bucket),
+				Average:     avg,
+				StdDev:      stdDev(values, avg),
+				Count:       len(values),
+			})
+		}
+
+		var trendDelta float64
+		if len(stats) > 1 {
+			trendDelta = stats[len(stats)-1].Average - stats[0].Average
+		}
+
+		summaries = append(summaries, SeriesSummary{
+			Exchange:   k.exchange,
+			Category:   k.category,
+			Buckets:    stats,
+			TrendDelta: trendDelta,
+		})
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Exchange != summaries[j].Exchange {
+			return summaries[i].Exchange < summaries[j].Exchange
+		}
+		return summaries[i].Category < summaries[j].Category
+	})
+
+	return summaries
+}
+
+func averageInts(values []int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0
+	for _, v := range values {
+		sum += v
+	}
+	return float64(sum) / float64(len(values))
+}
+
+func stdDev(values []int, mean float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sumSquares float64
+	for _, v := range values {
+		diff := float64(v) - mean
+		sumSquares += diff * diff
+	}
+	return math.Sqrt(sumSquares / float64(len(values)))
+}
@@ -0,0 +1,34 @@
+package lbtas
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestReadEventsMissingFile(t *testing.T) {
+	events, err := ReadEvents(filepath.Join(t.TempDir(), "does-not-exist.jsonl"))
+	if err != nil {
+		t.Fatalf("ReadEvents() err = %v, want nil", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("ReadEvents() = %v, want empty", events)
+	}
+}
+
+func TestReadEventsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	recorder := NewRecorder(path)
+
+	want := Event{Exchange: "nile", Criterion: "reliability", Rating: 3}
+	if err := recorder.Append(want); err != nil {
+		t.Fatalf("Append() err = %v", err)
+	}
+
+	got, err := ReadEvents(path)
+	if err != nil {
+		t.Fatalf("ReadEvents() err = %v", err)
+	}
+	if len(got) != 1 || got[0].Exchange != want.Exchange || got[0].Criterion != want.Criterion || got[0].Rating != want.Rating {
+		t.Fatalf("ReadEvents() = %+v, want [%+v]", got, want)
+	}
+}
@@ -0,0 +1,141 @@
+package lbtas
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// Store is the persistence backend behind a LevesonRatingSystem. It exists
+// as its own interface so that alternative backends (SQLite, BoltDB, ...)
+// can be dropped in later without touching AddRating/AddExchange.
+type Store interface {
+	// Load returns the current state.
+	Load() (StorageData, error)
+
+	// Update runs a read-modify-write cycle: it loads the latest state,
+	// passes it to fn, and persists whatever fn returns. Implementations
+	// must serialize Update calls - including ones from other
+	// processes - so two concurrent callers can't clobber each other's
+	// writes. If fn returns an error, nothing is persisted.
+	Update(fn func(StorageData) (StorageData, error)) error
+}
+
+// FileStore is the default Store: a single JSON file, guarded by an
+// advisory flock on a sidecar ".lock" file for the duration of each
+// read-modify-write cycle, with writes made atomic via a temp file plus
+// rename.
+type FileStore struct {
+	path string
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (fs *FileStore) Load() (StorageData, error) {
+	data := make(StorageData)
+
+	raw, err := os.ReadFile(fs.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return data, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (fs *FileStore) Update(fn func(StorageData) (StorageData, error)) error {
+	unlock, err := fs.lock()
+	if err != nil {
+		return fmt.Errorf("lbtas: locking %s: %w", fs.lockPath(), err)
+	}
+	defer unlock()
+
+	// Re-read under the lock: another process may have appended ratings
+	// since this LevesonRatingSystem was constructed or last wrote.
+	data, err := fs.Load()
+	if err != nil {
+		return err
+	}
+
+	updated, err := fn(data)
+	if err != nil {
+		return err
+	}
+
+	return fs.writeAtomic(updated)
+}
+
+func (fs *FileStore) lockPath() string {
+	return fs.path + ".lock"
+}
+
+func (fs *FileStore) lock() (func(), error) {
+	f, err := os.OpenFile(fs.lockPath(), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+func (fs *FileStore) writeAtomic(data StorageData) error {
+	tmpPath := fs.path + ".tmp"
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(tmpPath, raw, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, fs.path)
+}
+
+// memStore is an in-process Store with no on-disk footprint, used when a
+// LevesonRatingSystem is constructed with an empty storage path (tests,
+// one-off reports).
+type memStore struct {
+	mu   sync.Mutex
+	data StorageData
+}
+
+func newMemStore() *memStore {
+	return &memStore{data: make(StorageData)}
+}
+
+func (m *memStore) Load() (StorageData, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.data, nil
+}
+
+func (m *memStore) Update(fn func(StorageData) (StorageData, error)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	updated, err := fn(m.data)
+	if err != nil {
+		return err
+	}
+	m.data = updated
+	return nil
+}
@@ -0,0 +1,59 @@
+package lbtas
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// TestFileStoreConcurrentUpdate exercises the flock-guarded read-modify-write
+// cycle: many goroutines appending a rating to the same exchange through
+// independent FileStore instances (as separate `lbtas` processes would) must
+// never clobber each other's writes.
+func TestFileStoreConcurrentUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratings.json")
+
+	const writers = 20
+	var wg sync.WaitGroup
+	wg.Add(writers)
+	for i := 0; i < writers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			store := NewFileStore(path)
+			err := store.Update(func(data StorageData) (StorageData, error) {
+				exchange := data["nile"]
+				exchange.Reliability = append(exchange.Reliability, Rating{Value: i})
+				exchange.Metadata.TotalRatings++
+				data["nile"] = exchange
+				return data, nil
+			})
+			if err != nil {
+				t.Errorf("Update() err = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	store := NewFileStore(path)
+	data, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() err = %v", err)
+	}
+	if got := len(data["nile"].Reliability); got != writers {
+		t.Fatalf("Reliability has %d ratings, want %d (a concurrent Update clobbered another's write)", got, writers)
+	}
+	if got := data["nile"].Metadata.TotalRatings; got != writers {
+		t.Fatalf("TotalRatings = %d, want %d", got, writers)
+	}
+}
+
+func TestFileStoreLoadMissingFile(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "does-not-exist.json"))
+	data, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() err = %v, want nil", err)
+	}
+	if len(data) != 0 {
+		t.Fatalf("Load() = %v, want empty", data)
+	}
+}